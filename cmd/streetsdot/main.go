@@ -0,0 +1,38 @@
+// Command streetsdot reads the same graph JSON that streets.NewGraphFromJSON
+// consumes and prints it as Graphviz DOT on stdout.
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/rs/zerolog/log"
+
+	"pchpc/streets"
+	"pchpc/streets/viz"
+)
+
+func main() {
+	path := flag.String("graph", "", "path to the graph JSON file")
+	directed := flag.Bool("directed", true, "emit a digraph")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatal().Msg("missing -graph")
+	}
+
+	jsonBytes, err := os.ReadFile(*path)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to read graph file")
+	}
+
+	g, err := streets.NewGraphFromJSON(jsonBytes)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to parse graph file")
+	}
+
+	cg := streets.NewCustomGraph(g)
+	if err := viz.WriteDOT(os.Stdout, g, &cg, viz.Options{Directed: *directed}); err != nil {
+		log.Fatal().Err(err).Msg("failed to write DOT")
+	}
+}
@@ -0,0 +1,60 @@
+package streets
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dominikbraun/graph"
+)
+
+// Graph wraps a library graph.Graph[int, GVertex] with a cache of *Edge
+// wrappers, giving callers (Vehicle, Partition) a place to look up the
+// shared queueing state -- Q, per-vehicle position, mutex -- that a plain
+// graph.Edge[GVertex] doesn't carry.
+//
+// mu is a pointer so Graph stays cheap and safe to copy by value, which
+// NewVehicle's constructor signature (graph Graph) relies on.
+type Graph struct {
+	g graph.Graph[int, GVertex]
+
+	mu    *sync.Mutex
+	edges map[edgeKey]*Edge
+}
+
+// NewCustomGraph wraps g for Edge lookups.
+func NewCustomGraph(g graph.Graph[int, GVertex]) Graph {
+	return Graph{
+		g:     g,
+		mu:    &sync.Mutex{},
+		edges: make(map[edgeKey]*Edge),
+	}
+}
+
+// GetCorrespondingEdge returns the cached *Edge between from and to,
+// building it from the underlying graph.Graph (and its EdgeData) the first
+// time it's asked for. Repeated calls for the same pair of vertices return
+// the same *Edge, so its Q and per-vehicle positions stay consistent across
+// every caller that resolves the edge through this Graph.
+func (cg *Graph) GetCorrespondingEdge(from, to *GVertex) (*Edge, error) {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+
+	key := edgeKey{From: from.ID, To: to.ID}
+	if e, ok := cg.edges[key]; ok {
+		return e, nil
+	}
+
+	libEdge, err := cg.g.Edge(from.ID, to.ID)
+	if err != nil {
+		return nil, fmt.Errorf("no edge from %d to %d: %w", from.ID, to.ID, err)
+	}
+
+	data, ok := libEdge.Properties.Data.(EdgeData)
+	if !ok {
+		return nil, fmt.Errorf("edge %d->%d has no EdgeData", from.ID, to.ID)
+	}
+
+	edge := NewEdge(fmt.Sprintf("%d->%d", from.ID, to.ID), data)
+	cg.edges[key] = edge
+	return edge, nil
+}
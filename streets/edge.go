@@ -0,0 +1,124 @@
+package streets
+
+import (
+	"math"
+	"sync"
+
+	"github.com/gammazero/deque"
+)
+
+// Edge is the runtime counterpart to EdgeData: it tracks which Vehicles are
+// currently on the edge, in FIFO entry order (Q), and how far each of them
+// has traveled along it. PushVehicle/PopVehicle/FrontVehicle are safe for
+// concurrent use, since a partitioned Simulator may have two goroutines
+// resolve the same boundary edge from different Partitions in the same
+// step.
+type Edge struct {
+	ID string
+	EdgeData
+	Q *deque.Deque[*Vehicle]
+
+	mu        sync.Mutex
+	positions map[string]float64 // Vehicle.ID -> distance traveled along this edge
+}
+
+// NewEdge creates an Edge with the given ID over data.
+func NewEdge(id string, data EdgeData) *Edge {
+	var q deque.Deque[*Vehicle]
+	return &Edge{
+		ID:        id,
+		EdgeData:  data,
+		Q:         &q,
+		positions: make(map[string]float64),
+	}
+}
+
+// GetPosition returns v's index in the edge's queue, or NotInQueue if it
+// isn't currently on this edge.
+func (e *Edge) GetPosition(v *Vehicle) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i := 0; i < e.Q.Len(); i++ {
+		if e.Q.At(i).ID == v.ID {
+			return i
+		}
+	}
+	return NotInQueue
+}
+
+// PushVehicle enqueues v at the back of the edge and records it as
+// starting at distance 0 along the edge.
+func (e *Edge) PushVehicle(v *Vehicle) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.Q.PushBack(v)
+	e.positions[v.ID] = 0
+}
+
+// PopVehicle dequeues and returns the vehicle at the front of the edge, or
+// nil if the edge is empty.
+func (e *Edge) PopVehicle() *Vehicle {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.Q.Len() == 0 {
+		return nil
+	}
+
+	v := e.Q.PopFront()
+	delete(e.positions, v.ID)
+	return v
+}
+
+// SetPosition records how far v has traveled along this edge. Vehicle.drive
+// calls this every Step so FrontVehicle can find the true nearest leader.
+func (e *Edge) SetPosition(v *Vehicle, distance float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.positions[v.ID] = distance
+}
+
+// Position returns how far v has traveled along this edge.
+func (e *Edge) Position(v *Vehicle) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.positions[v.ID]
+}
+
+// FrontVehicle returns the vehicle immediately ahead of v on this edge, by
+// actual distance traveled rather than queue order, or nil if v is in the
+// lead. Two vehicles can enter an edge out of position order (e.g. after a
+// handoff), so the nearest-by-position vehicle is not always Q's next
+// entry.
+func (e *Edge) FrontVehicle(v *Vehicle) *Vehicle {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	myPos := e.positions[v.ID]
+
+	var leader *Vehicle
+	bestGap := math.Inf(1)
+
+	for i := 0; i < e.Q.Len(); i++ {
+		other := e.Q.At(i)
+		if other.ID == v.ID {
+			continue
+		}
+
+		pos, ok := e.positions[other.ID]
+		if !ok || pos <= myPos {
+			continue
+		}
+
+		if gap := pos - myPos; gap < bestGap {
+			bestGap = gap
+			leader = other
+		}
+	}
+
+	return leader
+}
@@ -0,0 +1,61 @@
+package streets
+
+import "testing"
+
+// TestEdgeFrontVehicleUsesPositionNotQueueOrder verifies FrontVehicle picks
+// the nearest vehicle ahead by tracked distance, even when queue (entry)
+// order and position order disagree -- e.g. right after a handoff places a
+// vehicle into the middle of an edge it didn't enter from the start of.
+func TestEdgeFrontVehicleUsesPositionNotQueueOrder(t *testing.T) {
+	edge := NewEdge("e1", EdgeData{Length: 100, MaxSpeed: 20})
+
+	behind := &Vehicle{ID: "behind"}
+	ahead := &Vehicle{ID: "ahead"}
+
+	// Entry order puts "ahead" first, but it is actually behind "behind" in
+	// real position -- FrontVehicle must not be fooled by queue order.
+	edge.PushVehicle(ahead)
+	edge.PushVehicle(behind)
+
+	edge.SetPosition(ahead, 10)
+	edge.SetPosition(behind, 40)
+
+	if leader := edge.FrontVehicle(behind); leader != nil {
+		t.Fatalf("expected no leader ahead of %q, got %q", behind.ID, leader.ID)
+	}
+
+	leader := edge.FrontVehicle(ahead)
+	if leader == nil || leader.ID != behind.ID {
+		t.Fatalf("expected %q to lead %q, got %v", behind.ID, ahead.ID, leader)
+	}
+}
+
+func TestEdgePushPopVehicleTracksQueueAndPosition(t *testing.T) {
+	edge := NewEdge("e1", EdgeData{Length: 100, MaxSpeed: 20})
+	v := &Vehicle{ID: "v1"}
+
+	if edge.GetPosition(v) != NotInQueue {
+		t.Fatalf("expected v1 not to be queued yet")
+	}
+
+	edge.PushVehicle(v)
+	if edge.GetPosition(v) != 0 {
+		t.Fatalf("expected v1 at queue position 0")
+	}
+	if edge.Position(v) != 0 {
+		t.Fatalf("expected v1 to start at distance 0")
+	}
+
+	edge.SetPosition(v, 55)
+	if edge.Position(v) != 55 {
+		t.Fatalf("expected v1 at distance 55, got %v", edge.Position(v))
+	}
+
+	popped := edge.PopVehicle()
+	if popped.ID != v.ID {
+		t.Fatalf("expected to pop v1, got %v", popped)
+	}
+	if edge.GetPosition(v) != NotInQueue {
+		t.Fatalf("expected v1 to be removed from the queue")
+	}
+}
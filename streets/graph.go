@@ -9,11 +9,20 @@ import (
 	"pchpc/utils"
 )
 
+// GVertex is a vertex in a streets graph: an OSM node ID placed at (X, Y).
+type GVertex struct {
+	ID   int
+	X, Y float64
+}
+
 // EdgeData is the data stored in an edge
 type EdgeData struct {
 	MaxSpeed float64
 	Length   float64
 	Map      *utils.HashMap[string, *Vehicle]
+	// Windows optionally scripts time-varying cost for this edge, e.g. rush
+	// hours or closures. See EdgeWeight and Scheduled.
+	Windows []TimeWindow
 }
 
 // NewGraphFromJSON creates a new graph from a JSON input
@@ -49,6 +58,12 @@ func NewGraph(vertices []JVertex, edges []JEdge) graph.Graph[int, GVertex] {
 		}
 
 		hMap := utils.NewMap[string, *Vehicle]()
+
+		var windows []TimeWindow
+		if w, ok := any(edge).(windowed); ok {
+			windows = w.TimeWindows()
+		}
+
 		_ = g.AddEdge(
 			edge.From,
 			edge.To,
@@ -56,6 +71,7 @@ func NewGraph(vertices []JVertex, edges []JEdge) graph.Graph[int, GVertex] {
 				MaxSpeed: msf,
 				Length:   edge.Length,
 				Map:      &hMap,
+				Windows:  windows,
 			}))
 	}
 
@@ -0,0 +1,23 @@
+package streets
+
+import "math"
+
+// IDMAccel computes an Intelligent Driver Model acceleration.
+//
+// speed is the vehicle's current speed, desiredSpeed (v0) its free-flow
+// target, gap the bumper-to-bumper distance to the vehicle ahead (pass
+// math.Inf(1) when there is no leader), leaderSpeed the leader's speed, s0
+// the minimum gap, t the desired time headway, a the maximum acceleration
+// and b the comfortable deceleration.
+func IDMAccel(speed, desiredSpeed, gap, leaderSpeed, s0, t, a, b float64) float64 {
+	freeFlowTerm := math.Pow(speed/desiredSpeed, 4)
+
+	if math.IsInf(gap, 1) {
+		return a * (1 - freeFlowTerm)
+	}
+
+	desiredGap := s0 + math.Max(0, speed*t+speed*(speed-leaderSpeed)/(2*math.Sqrt(a*b)))
+	interactionTerm := math.Pow(desiredGap/gap, 2)
+
+	return a * (1 - freeFlowTerm - interactionTerm)
+}
@@ -0,0 +1,65 @@
+package streets
+
+import (
+	"math"
+	"testing"
+)
+
+// TestIDMConvoyReachesEquilibriumGap simulates a two-vehicle convoy purely
+// numerically (no Edge/Graph involved): a leader cruising well below the
+// follower's desired speed, and a follower that starts too close behind
+// it. The follower should settle at the leader's speed with a gap
+// approaching its IDM desired gap. The follower's desired speed is set far
+// above the leader's so the free-flow term is negligible at equilibrium;
+// if the two desired speeds were equal, both vehicles would asymptote to
+// the same free-flow speed with no interaction left to hold a fixed gap,
+// and the gap would drift apart forever instead of converging.
+func TestIDMConvoyReachesEquilibriumGap(t *testing.T) {
+	const (
+		dt           = 0.1
+		desiredSpeed = 100.0
+		leaderSpeed  = 20.0
+		s0           = 2.0
+		timeHeadway  = 1.5
+		maxAccel     = 1.5
+		comfortDecel = 2.0
+	)
+
+	followerSpeed := 10.0
+	gap := 15.0
+
+	for i := 0; i < 20000; i++ {
+		accel := IDMAccel(followerSpeed, desiredSpeed, gap, leaderSpeed, s0, timeHeadway, maxAccel, comfortDecel)
+		followerSpeed += accel * dt
+		if followerSpeed < 0 {
+			followerSpeed = 0
+		}
+
+		relativeSpeed := leaderSpeed - followerSpeed
+		gap += relativeSpeed * dt
+	}
+
+	wantGap := s0 + leaderSpeed*timeHeadway
+
+	if math.Abs(followerSpeed-leaderSpeed) > 0.1 {
+		t.Fatalf("follower speed %.3f did not converge to leader speed %.3f", followerSpeed, leaderSpeed)
+	}
+	if math.Abs(gap-wantGap) > 0.5 {
+		t.Fatalf("gap %.3f did not converge to equilibrium gap %.3f", gap, wantGap)
+	}
+}
+
+// TestIDMFreeFlowAcceleratesTowardsDesiredSpeed checks that, with no leader,
+// a slower-than-desired vehicle accelerates and one already at its desired
+// speed neither accelerates nor decelerates.
+func TestIDMFreeFlowAcceleratesTowardsDesiredSpeed(t *testing.T) {
+	slow := IDMAccel(10, 20, math.Inf(1), 0, 2, 1.5, 1.5, 2)
+	if slow <= 0 {
+		t.Fatalf("expected positive acceleration below desired speed, got %v", slow)
+	}
+
+	atTarget := IDMAccel(20, 20, math.Inf(1), 0, 2, 1.5, 1.5, 2)
+	if math.Abs(atTarget) > 1e-9 {
+		t.Fatalf("expected ~zero acceleration at desired speed, got %v", atTarget)
+	}
+}
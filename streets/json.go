@@ -0,0 +1,47 @@
+package streets
+
+import "encoding/json"
+
+// JVertex is the JSON representation of a graph vertex.
+type JVertex struct {
+	OsmID int     `json:"osm_id"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+}
+
+// JEdge is the JSON representation of a graph edge. MaxSpeed is a string in
+// the source data (e.g. "50", sometimes empty), so NewGraph parses it
+// leniently. Windows is optional: scenario authors can attach scripted
+// rush hours or closures to an edge without touching the rest of the
+// schema.
+type JEdge struct {
+	From     int          `json:"from"`
+	To       int          `json:"to"`
+	MaxSpeed string       `json:"max_speed"`
+	Length   float64      `json:"length"`
+	Windows  []TimeWindow `json:"windows,omitempty"`
+}
+
+// TimeWindows implements windowed, so NewGraph can attach JEdge's optional
+// Windows to the EdgeData it builds without depending on this type directly.
+func (e JEdge) TimeWindows() []TimeWindow {
+	return e.Windows
+}
+
+// JGraph is the JSON representation of a full graph.
+type JGraph struct {
+	Vertices []JVertex `json:"vertices"`
+	Edges    []JEdge   `json:"edges"`
+}
+
+// JGraphWrapper is the top-level shape of a graph scenario file.
+type JGraphWrapper struct {
+	Graph JGraph `json:"graph"`
+}
+
+// UnmarshalGraphJSON parses a graph scenario file.
+func UnmarshalGraphJSON(data []byte) (JGraphWrapper, error) {
+	var wrapper JGraphWrapper
+	err := json.Unmarshal(data, &wrapper)
+	return wrapper, err
+}
@@ -0,0 +1,193 @@
+package streets
+
+import (
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandoffMsg is sent from a Partition to the Partition that owns the
+// vertex a Vehicle is entering, so it can be re-attached to the correct
+// Edge queue there.
+type HandoffMsg struct {
+	Vehicle       *Vehicle
+	EntryEdgeID   string
+	RemainingPath Path
+}
+
+// Partition wraps a Rect with its local graph (as produced by
+// GraphFromRect, wrapped in a Graph for Edge caching) and the set of
+// Vehicles currently being advanced inside it. Partitions run
+// independently except at step boundaries, where Simulator.Run hands off
+// vehicles that crossed into a neighboring Rect.
+type Partition struct {
+	Rect     Rect
+	Graph    *Graph
+	Vehicles []*Vehicle
+
+	// peers are the other Partitions in the same Simulator, consulted by
+	// step() to find who a vehicle leaving this Rect should be handed off
+	// to. Set by NewSimulator.
+	peers []*Partition
+
+	inbox chan HandoffMsg
+}
+
+// NewPartition builds a Partition for rect, deriving its local graph from
+// edges via GraphFromRect.
+func NewPartition(rect Rect, edges []RawEdge[int]) *Partition {
+	cg := NewCustomGraph(GraphFromRect(edges, rect))
+	return &Partition{
+		Rect:  rect,
+		Graph: &cg,
+		inbox: make(chan HandoffMsg, 64),
+	}
+}
+
+// owns reports whether the vertex the Vehicle is currently departing from
+// lies inside this Partition's Rect. A Vehicle is owned by the Partition
+// that contains the source end of its current edge, even when that edge
+// itself crosses into a neighboring Rect, so every edge has exactly one
+// owning Partition at any given time. It consults the Vehicle's own Path
+// rather than its home partition's graph directly, so ownership checks
+// stay cheap; Vehicle.GetCurrentEdge is the one that falls back to a
+// global index when the edge itself crosses a boundary.
+func (p *Partition) owns(v *Vehicle) bool {
+	if v.IsParked {
+		return false
+	}
+	return p.Rect.InRect(GVertex{ID: v.currentVertexID()})
+}
+
+// step advances every Vehicle this Partition currently owns by one tick,
+// draining any handoffs queued up from neighboring partitions first. A
+// vehicle whose Step() carries it out of this Rect is hand off to whichever
+// peer Partition now owns it, rather than simply dropped. Vehicles drained
+// from the inbox this tick are only registered as owned by p, not stepped
+// -- their origin partition already advanced them this tick before handing
+// them off, so stepping them again here would advance them twice in the
+// same tick.
+func (p *Partition) step() {
+	incoming := p.drainInbox()
+
+	remaining := p.Vehicles[:0]
+	for _, v := range p.Vehicles {
+		v.Step()
+
+		switch {
+		case v.IsParked:
+			// Reached its destination; nothing more to track.
+		case p.owns(v):
+			remaining = append(remaining, v)
+		default:
+			p.handOff(v)
+		}
+	}
+	p.Vehicles = append(remaining, incoming...)
+}
+
+// handOff routes v to whichever peer Partition now owns its current edge.
+// If no peer claims it (e.g. the synthetic graph under test doesn't cover
+// it), v is dropped with a warning rather than silently lost without a
+// trace.
+func (p *Partition) handOff(v *Vehicle) {
+	dest := p.destinationFor(v)
+	if dest == nil {
+		log.Warn().Msgf("vehicle %v left partition %v but no peer owns its new edge; dropping it", v.ID, p.Rect)
+		return
+	}
+
+	entryEdgeID := ""
+	if v.CurrentEdge != nil {
+		entryEdgeID = v.CurrentEdge.ID
+	}
+
+	dest.Handoff(HandoffMsg{
+		Vehicle:       v,
+		EntryEdgeID:   entryEdgeID,
+		RemainingPath: v.Path,
+	})
+}
+
+// destinationFor returns the peer Partition that owns v's current edge, or
+// nil if none of them do.
+func (p *Partition) destinationFor(v *Vehicle) *Partition {
+	for _, peer := range p.peers {
+		if peer.owns(v) {
+			return peer
+		}
+	}
+	return nil
+}
+
+// drainInbox returns the Vehicles handed off from neighboring Partitions
+// since the last step, re-attaching each one's Path and HomePartition.
+func (p *Partition) drainInbox() []*Vehicle {
+	var incoming []*Vehicle
+	for {
+		select {
+		case msg := <-p.inbox:
+			v := msg.Vehicle
+			v.Path = msg.RemainingPath
+			v.HomePartition = p
+			incoming = append(incoming, v)
+			log.Debug().Msgf("Vehicle %v handed off into partition at edge %v", v.ID, msg.EntryEdgeID)
+		default:
+			return incoming
+		}
+	}
+}
+
+// Handoff delivers msg to this Partition's inbox, to be picked up at the
+// start of its next step.
+func (p *Partition) Handoff(msg HandoffMsg) {
+	p.inbox <- msg
+}
+
+// Simulator ticks a set of Partitions in lockstep, so the overall model
+// stays deterministic even though each Partition advances its own
+// vehicles concurrently.
+type Simulator struct {
+	Partitions []*Partition
+}
+
+// NewSimulator builds a Simulator over partitions, wiring each Partition's
+// peers so a vehicle that crosses a boundary during step() can be handed
+// off to the Partition that now owns it. Every Vehicle already assigned to
+// a Partition has its HomePartition set accordingly.
+func NewSimulator(partitions []*Partition) *Simulator {
+	for _, p := range partitions {
+		p.peers = make([]*Partition, 0, len(partitions)-1)
+		for _, other := range partitions {
+			if other != p {
+				p.peers = append(p.peers, other)
+			}
+		}
+		for _, v := range p.Vehicles {
+			v.HomePartition = p
+		}
+	}
+
+	return &Simulator{Partitions: partitions}
+}
+
+// Run advances every Partition for the given number of steps. Each step is
+// a barrier: every Partition's goroutine must finish stepping before the
+// next step begins, so handoffs queued during step N are only visible to
+// their destination Partition's inbox at the start of step N+1.
+func (s *Simulator) Run(steps int) {
+	for i := 0; i < steps; i++ {
+		var wg sync.WaitGroup
+		wg.Add(len(s.Partitions))
+
+		for _, p := range s.Partitions {
+			p := p
+			go func() {
+				defer wg.Done()
+				p.step()
+			}()
+		}
+
+		wg.Wait()
+	}
+}
@@ -0,0 +1,125 @@
+package streets
+
+import (
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+// gridEdges builds the RawEdges for an n x n grid, used to synthesize a
+// partitionable graph for the scaling benchmark below.
+func gridEdges(n int) []RawEdge[int] {
+	edges := make([]RawEdge[int], 0, 2*n*n)
+	id := func(x, y int) int { return y*n + x }
+
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if x+1 < n {
+				edges = append(edges, RawEdge[int]{Source: id(x, y), Target: id(x+1, y)})
+			}
+			if y+1 < n {
+				edges = append(edges, RawEdge[int]{Source: id(x, y), Target: id(x, y+1)})
+			}
+		}
+	}
+	return edges
+}
+
+func gridVertices(n int) []GVertex {
+	vertices := make([]GVertex, 0, n*n)
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			vertices = append(vertices, GVertex{ID: y*n + x, X: float64(x), Y: float64(y)})
+		}
+	}
+	return vertices
+}
+
+// globalGridGraph builds the full n x n grid as a Graph with real EdgeData,
+// for resolving vehicle path lengths; the per-partition graphs returned by
+// GraphFromRect only carry topology.
+func globalGridGraph(n int, edges []RawEdge[int], vertices []GVertex) Graph {
+	hashFn := func(v GVertex) int { return v.ID }
+	g := graph.New(hashFn, graph.Directed())
+
+	for _, v := range vertices {
+		_ = g.AddVertex(v)
+	}
+	for _, e := range edges {
+		_ = g.AddEdge(e.Source, e.Target, graph.EdgeData(EdgeData{MaxSpeed: 10, Length: 10}))
+	}
+
+	return NewCustomGraph(g)
+}
+
+// benchmarkPartitions builds an n-partition Simulator over a synthetic grid,
+// fills every partition with one vehicle per row driving along that row's
+// local columns, and runs it for a fixed number of steps. Giving each
+// partition its own share of traffic is what actually exercises the
+// per-partition step loop; an empty grid would just measure
+// goroutine/channel spin-up and wouldn't show partitioning's scaling
+// benefit at all.
+func benchmarkPartitions(b *testing.B, n int) {
+	const gridSize = 32
+	edges := gridEdges(gridSize)
+	vertices := gridVertices(gridSize)
+
+	rects := make([]Rect, n)
+	width := float64(gridSize) / float64(n)
+	for i := 0; i < n; i++ {
+		rects[i] = Rect{
+			BotLeft:  Point{X: float64(i) * width, Y: 0},
+			TopRight: Point{X: float64(i+1) * width, Y: float64(gridSize)},
+		}
+		for _, v := range vertices {
+			if v.X >= rects[i].BotLeft.X && v.X < rects[i].TopRight.X {
+				rects[i].Vertices = append(rects[i].Vertices, v)
+			}
+		}
+	}
+
+	partitions := make([]*Partition, n)
+	for i, rect := range rects {
+		partitions[i] = NewPartition(rect, edges)
+	}
+	sim := NewSimulator(partitions)
+
+	// Vehicles are built against a global graph carrying real EdgeData
+	// (MaxSpeed/Length): Partition.Graph, built by GraphFromRect, only
+	// knows topology, so NewVehicle's initial GetPathLengths call would
+	// panic resolving an edge against it.
+	global := globalGridGraph(gridSize, edges, vertices)
+
+	for i, rect := range rects {
+		localWidth := len(rect.Vertices) / gridSize
+		if localWidth < 2 {
+			continue
+		}
+		for row := 0; row < gridSize; row++ {
+			rowVertices := rect.Vertices[row*localWidth : (row+1)*localWidth]
+			path := Path{Vertices: append([]GVertex(nil), rowVertices...)}
+			v := NewVehicle(path, 10, global)
+			v.HomePartition = partitions[i]
+			partitions[i].Vehicles = append(partitions[i].Vehicles, &v)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sim.Run(10)
+	}
+}
+
+func BenchmarkPartitions1(b *testing.B) { benchmarkPartitions(b, 1) }
+func BenchmarkPartitions2(b *testing.B) { benchmarkPartitions(b, 2) }
+func BenchmarkPartitions4(b *testing.B) { benchmarkPartitions(b, 4) }
+func BenchmarkPartitions8(b *testing.B) { benchmarkPartitions(b, 8) }
+
+func TestGridEdgesCount(t *testing.T) {
+	const n = 4
+	edges := gridEdges(n)
+	want := 2 * n * (n - 1)
+	if len(edges) != want {
+		t.Fatalf("gridEdges(%d) produced %d edges, want %d", n, len(edges), want)
+	}
+}
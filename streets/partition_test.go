@@ -0,0 +1,87 @@
+package streets
+
+import (
+	"testing"
+
+	"github.com/dominikbraun/graph"
+
+	"pchpc/utils"
+)
+
+// buildTwoPartitionGraph builds a 4-vertex chain 1->2->3->4, split into two
+// partitions: {1, 2} and {3, 4}, with edge 2->3 crossing the boundary.
+func buildTwoPartitionGraph(t *testing.T) (lib graph.Graph[int, GVertex], edges []RawEdge[int], left, right Rect) {
+	t.Helper()
+
+	hashFn := func(v GVertex) int { return v.ID }
+	lib = graph.New(hashFn, graph.Directed())
+
+	vertices := []GVertex{
+		{ID: 1, X: 0, Y: 0},
+		{ID: 2, X: 1, Y: 0},
+		{ID: 3, X: 2, Y: 0},
+		{ID: 4, X: 3, Y: 0},
+	}
+	for _, v := range vertices {
+		if err := lib.AddVertex(v); err != nil {
+			t.Fatalf("AddVertex: %v", err)
+		}
+	}
+
+	rawEdges := []RawEdge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 3}, {Source: 3, Target: 4}}
+	for _, e := range rawEdges {
+		m := utils.NewMap[string, *Vehicle]()
+		if err := lib.AddEdge(e.Source, e.Target, graph.EdgeData(EdgeData{MaxSpeed: 10, Length: 10, Map: &m})); err != nil {
+			t.Fatalf("AddEdge: %v", err)
+		}
+	}
+
+	left = Rect{BotLeft: Point{X: 0, Y: 0}, TopRight: Point{X: 1.5, Y: 0}, Vertices: vertices[:2]}
+	right = Rect{BotLeft: Point{X: 1.5, Y: 0}, TopRight: Point{X: 3, Y: 0}, Vertices: vertices[2:]}
+
+	return lib, rawEdges, left, right
+}
+
+func TestPartitionHandsOffVehicleAcrossBoundary(t *testing.T) {
+	lib, edges, leftRect, rightRect := buildTwoPartitionGraph(t)
+
+	leftPartition := NewPartition(leftRect, edges)
+	rightPartition := NewPartition(rightRect, edges)
+	sim := NewSimulator([]*Partition{leftPartition, rightPartition})
+
+	globalGraph := NewCustomGraph(lib)
+
+	path := Path{Vertices: []GVertex{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}}
+	v := NewVehicle(path, 10, globalGraph)
+	v.HomePartition = leftPartition
+	leftPartition.Vehicles = append(leftPartition.Vehicles, &v)
+
+	if !leftPartition.owns(&v) {
+		t.Fatalf("expected the new vehicle to start inside the left partition")
+	}
+
+	// Step until the vehicle crosses 2->3, the boundary edge.
+	for i := 0; i < 5 && !rightPartitionHasVehicle(rightPartition, v.ID); i++ {
+		sim.Run(1)
+	}
+
+	if !rightPartitionHasVehicle(rightPartition, v.ID) {
+		t.Fatalf("expected vehicle %v to be handed off to the right partition", v.ID)
+	}
+	if leftPartitionHasVehicle(leftPartition, v.ID) {
+		t.Fatalf("expected vehicle %v to be removed from the left partition after handoff", v.ID)
+	}
+}
+
+func rightPartitionHasVehicle(p *Partition, id string) bool {
+	for _, v := range p.Vehicles {
+		if v.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func leftPartitionHasVehicle(p *Partition, id string) bool {
+	return rightPartitionHasVehicle(p, id)
+}
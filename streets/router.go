@@ -0,0 +1,289 @@
+package streets
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+
+	"github.com/gammazero/deque"
+	"github.com/rs/zerolog/log"
+)
+
+// Step counts simulation ticks elapsed since the simulation started.
+type Step int64
+
+// MinSpeed is the floor used when deriving effective travel time, so a fully
+// congested edge degrades gracefully instead of producing an infinite weight.
+const MinSpeed = 1.0
+
+// ReroutePolicy controls how eagerly a Vehicle re-plans its remaining Path.
+type ReroutePolicy struct {
+	// CooldownSteps is the minimum number of Steps between two reroutes of the same Vehicle.
+	CooldownSteps Step
+	// DeltaThreshold is the minimum improvement, in seconds of travel time, the
+	// candidate path must offer over the remaining cost of the current path
+	// before it replaces it.
+	DeltaThreshold float64
+}
+
+// DefaultReroutePolicy is a conservative policy suitable for most scenarios.
+var DefaultReroutePolicy = ReroutePolicy{
+	CooldownSteps:  20,
+	DeltaThreshold: 5,
+}
+
+type edgeKey struct{ From, To int }
+
+// Router computes and caches edge weights (congestion-aware unless Weight is
+// replaced) over a graph and
+// answers shortest-path queries against them. It is safe to share across
+// multiple Vehicles; edge weights are recalculated lazily, similar in spirit
+// to EtherGuard's recalculateTime/cooldown approach, so a busy simulation
+// doesn't pay the full Dijkstra cost on every tick for every vehicle.
+type Router struct {
+	cg       *Graph
+	cooldown Step
+	now      Step
+	weights  map[edgeKey]float64
+	calcedAt map[edgeKey]Step
+
+	// Weight is the EdgeWeight the Router evaluates when a cached cost
+	// expires. Defaults to CongestionAware.
+	Weight EdgeWeight
+}
+
+// NewRouter creates a Router over cg. cg must be the same Graph that
+// Vehicles traveling this Router's edges resolve their *Edge through (e.g.
+// a Vehicle's Graph, or a Partition's), so weight functions like
+// CongestionAware see the same live Edge.Q/positions those Vehicles are
+// actually updating, rather than a disconnected view of occupancy. cooldown
+// is the number of Steps an edge's cached weight is trusted before being
+// recalculated. Weight defaults to CongestionAware; set r.Weight to use a
+// different EdgeWeight.
+func NewRouter(cg *Graph, cooldown Step) *Router {
+	return &Router{
+		cg:       cg,
+		cooldown: cooldown,
+		weights:  make(map[edgeKey]float64),
+		calcedAt: make(map[edgeKey]Step),
+		Weight:   CongestionAware{},
+	}
+}
+
+// Tick advances the Router's notion of the current Step. Simulator code
+// should call this once per tick.
+func (r *Router) Tick() {
+	r.now++
+}
+
+// Now returns the Step the Router currently believes it is at.
+func (r *Router) Now() Step {
+	return r.now
+}
+
+// congestionFactor turns a queue length into a value in [0, 1) that discounts
+// the free-flow speed of an edge. It saturates rather than going to zero so a
+// jammed edge is expensive, not impassable.
+func congestionFactor(queueLen int) float64 {
+	if queueLen <= 0 {
+		return 0
+	}
+	return 1 - 1/(1+float64(queueLen)/4)
+}
+
+// edgeWeight returns r.Weight's cost for the edge src->dst at the Router's
+// current Step. The result is cached for r.cooldown Steps before being
+// recomputed. It resolves src->dst through r.cg, the same cache
+// PushVehicle/PopVehicle update, so the weight reflects live occupancy
+// rather than a snapshot of EdgeData.
+func (r *Router) edgeWeight(src, dst int) (float64, error) {
+	key := edgeKey{src, dst}
+	if last, ok := r.calcedAt[key]; ok && r.now-last < r.cooldown {
+		return r.weights[key], nil
+	}
+
+	from, to := GVertex{ID: src}, GVertex{ID: dst}
+	edge, err := r.cg.GetCorrespondingEdge(&from, &to)
+	if err != nil {
+		return 0, err
+	}
+
+	weight := r.Weight.Cost(edge, r.now)
+
+	r.weights[key] = weight
+	r.calcedAt[key] = r.now
+
+	return weight, nil
+}
+
+// pqItem is an entry in the Dijkstra priority queue.
+type pqItem struct {
+	vertex int
+	dist   float64
+	index  int
+}
+
+type priorityQueue []*pqItem
+
+func (pq priorityQueue) Len() int            { return len(pq) }
+func (pq priorityQueue) Less(i, j int) bool  { return pq[i].dist < pq[j].dist }
+func (pq priorityQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].index, pq[j].index = i, j
+}
+func (pq *priorityQueue) Push(x interface{}) {
+	item := x.(*pqItem)
+	item.index = len(*pq)
+	*pq = append(*pq, item)
+}
+func (pq *priorityQueue) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*pq = old[:n-1]
+	return item
+}
+
+// ShortestPath runs Dijkstra's algorithm from src to dst using the Router's
+// edge weights (r.Weight), returning the vertex IDs on the path and its
+// total cost. Edges whose weight is +Inf, such as ones closed by a
+// Scheduled TimeWindow, are not traversed.
+func (r *Router) ShortestPath(src, dst int) ([]int, float64, error) {
+	adjacency, err := r.cg.g.AdjacencyMap()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dist := map[int]float64{src: 0}
+	prev := map[int]int{}
+	visited := map[int]bool{}
+
+	pq := &priorityQueue{{vertex: src, dist: 0}}
+	heap.Init(pq)
+
+	for pq.Len() > 0 {
+		cur := heap.Pop(pq).(*pqItem)
+		if visited[cur.vertex] {
+			continue
+		}
+		visited[cur.vertex] = true
+
+		if cur.vertex == dst {
+			break
+		}
+
+		for next := range adjacency[cur.vertex] {
+			weight, err := r.edgeWeight(cur.vertex, next)
+			if err != nil || math.IsInf(weight, 1) {
+				continue
+			}
+
+			candidate := dist[cur.vertex] + weight
+			if d, ok := dist[next]; !ok || candidate < d {
+				dist[next] = candidate
+				prev[next] = cur.vertex
+				heap.Push(pq, &pqItem{vertex: next, dist: candidate})
+			}
+		}
+	}
+
+	cost, reached := dist[dst]
+	if !reached {
+		return nil, 0, fmt.Errorf("no path from %d to %d", src, dst)
+	}
+
+	path := []int{dst}
+	for v := dst; v != src; {
+		p, ok := prev[v]
+		if !ok {
+			return nil, 0, fmt.Errorf("no path from %d to %d", src, dst)
+		}
+		path = append(path, p)
+		v = p
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path, cost, nil
+}
+
+// remainingCost sums the Router's current edge weights for the vehicle's
+// unfinished Path, starting at its current position in the path.
+func (r *Router) remainingCost(v *Vehicle) float64 {
+	total := 0.0
+	for i := v.pathIndex; i < len(v.Path.Vertices)-1; i++ {
+		w, err := r.edgeWeight(v.Path.Vertices[i].ID, v.Path.Vertices[i+1].ID)
+		if err != nil {
+			continue
+		}
+		total += w
+	}
+	return total
+}
+
+// Reroute asks v's Router for a fresh path from v's current vertex to
+// destination, and adopts it if the policy allows. A reroute is only
+// considered when v is at a vertex boundary (not mid-edge), the cooldown
+// since the last reroute has elapsed, and the candidate path beats the
+// remaining cost of the current path by at least DeltaThreshold.
+func (v *Vehicle) Reroute(destination GVertex) error {
+	if v.Router == nil {
+		return fmt.Errorf("vehicle %v has no Router configured", v.ID)
+	}
+	if v.IsParked {
+		return nil
+	}
+	if v.CurrentEdge != nil && v.CurrentEdge.GetPosition(v) != NotInQueue {
+		return nil
+	}
+	if v.Router.now-v.lastRerouteAt < v.ReroutePolicy.CooldownSteps {
+		return nil
+	}
+
+	candidate, cost, err := v.Router.ShortestPath(v.currentVertexID(), destination.ID)
+	if err != nil {
+		return err
+	}
+
+	remaining := v.Router.remainingCost(v)
+	if remaining-cost < v.ReroutePolicy.DeltaThreshold {
+		return nil
+	}
+
+	newVertices := make([]GVertex, 0, len(candidate))
+	for _, id := range candidate {
+		vertex, err := v.Router.cg.g.Vertex(id)
+		if err != nil {
+			return err
+		}
+		newVertices = append(newVertices, vertex)
+	}
+
+	v.Path = Path{Vertices: newVertices}
+	v.pathIndex = 0
+
+	var q deque.Deque[float64]
+	for _, length := range v.GetPathLengths() {
+		if length != 0 {
+			q.PushBack(length)
+		}
+	}
+	v.PathLength = &q
+	v.lastRerouteAt = v.Router.now
+
+	log.Info().Msgf("Vehicle %v rerouted to %v, new cost %.2f (was %.2f)", v.ID, destination.ID, cost, remaining)
+
+	return nil
+}
+
+// currentVertexID returns the ID of the vertex the Vehicle is currently
+// standing at, i.e. the source of its current (or next) edge.
+func (v *Vehicle) currentVertexID() int {
+	if v.pathIndex >= len(v.Path.Vertices) {
+		return v.Path.Vertices[len(v.Path.Vertices)-1].ID
+	}
+	return v.Path.Vertices[v.pathIndex].ID
+}
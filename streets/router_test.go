@@ -0,0 +1,142 @@
+package streets
+
+import (
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+// buildDiamondGraph builds a 4-vertex diamond: 1 -> {2, 3} -> 4, where the
+// direct 1->2->4 route is shorter on paper but 2->4 is artificially congested
+// by queuing jam vehicles onto its *Edge, the same way PushVehicle does in
+// the live simulation, so Router sees it through the same cache a Vehicle
+// would.
+func buildDiamondGraph(t *testing.T, jam int) *Graph {
+	t.Helper()
+
+	hashFn := func(v GVertex) int { return v.ID }
+	g := graph.New(hashFn, graph.Directed())
+
+	for id := 1; id <= 4; id++ {
+		if err := g.AddVertex(GVertex{ID: id}); err != nil {
+			t.Fatalf("AddVertex(%d): %v", id, err)
+		}
+	}
+
+	addEdge := func(from, to int, length, maxSpeed float64) {
+		err := g.AddEdge(from, to, graph.EdgeData(EdgeData{
+			MaxSpeed: maxSpeed,
+			Length:   length,
+		}))
+		if err != nil {
+			t.Fatalf("AddEdge(%d, %d): %v", from, to, err)
+		}
+	}
+
+	addEdge(1, 2, 100, 20)
+	addEdge(2, 4, 100, 20)
+	addEdge(1, 3, 150, 20)
+	addEdge(3, 4, 150, 20)
+
+	cg := NewCustomGraph(g)
+
+	if jam > 0 {
+		from, to := GVertex{ID: 2}, GVertex{ID: 4}
+		edge, err := cg.GetCorrespondingEdge(&from, &to)
+		if err != nil {
+			t.Fatalf("GetCorrespondingEdge(2, 4): %v", err)
+		}
+		for i := 0; i < jam; i++ {
+			edge.PushVehicle(&Vehicle{ID: nanoidFor(i)})
+		}
+	}
+
+	return &cg
+}
+
+func nanoidFor(i int) string {
+	return string(rune('a' + i))
+}
+
+func TestRouterShortestPathPrefersFreeFlowRoute(t *testing.T) {
+	g := buildDiamondGraph(t, 0)
+	r := NewRouter(g, 100)
+
+	path, _, err := r.ShortestPath(1, 4)
+	if err != nil {
+		t.Fatalf("ShortestPath: %v", err)
+	}
+
+	want := []int{1, 2, 4}
+	if !equalInts(path, want) {
+		t.Fatalf("got path %v, want %v", path, want)
+	}
+}
+
+func TestRouterDivertsAroundCongestion(t *testing.T) {
+	// Jam the short route (2->4) hard enough that the longer, free-flowing
+	// route through 3 becomes cheaper.
+	g := buildDiamondGraph(t, 50)
+	r := NewRouter(g, 100)
+
+	path, _, err := r.ShortestPath(1, 4)
+	if err != nil {
+		t.Fatalf("ShortestPath: %v", err)
+	}
+
+	want := []int{1, 3, 4}
+	if !equalInts(path, want) {
+		t.Fatalf("got path %v, want %v (expected diversion around congestion)", path, want)
+	}
+}
+
+func TestRouterCachesWeightsUntilCooldownElapses(t *testing.T) {
+	cg := buildDiamondGraph(t, 0)
+	r := NewRouter(cg, 5)
+
+	w1, err := r.edgeWeight(1, 2)
+	if err != nil {
+		t.Fatalf("edgeWeight: %v", err)
+	}
+
+	// Queue vehicles directly onto the Edge, the same way PushVehicle does
+	// in the live simulation, without advancing past the cooldown; the
+	// cached weight should still be returned.
+	from, to := GVertex{ID: 1}, GVertex{ID: 2}
+	edge, err := cg.GetCorrespondingEdge(&from, &to)
+	if err != nil {
+		t.Fatalf("GetCorrespondingEdge(1, 2): %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		edge.PushVehicle(&Vehicle{ID: nanoidFor(i)})
+	}
+
+	w2, err := r.edgeWeight(1, 2)
+	if err != nil {
+		t.Fatalf("edgeWeight: %v", err)
+	}
+	if w1 != w2 {
+		t.Fatalf("expected cached weight %v, got %v before cooldown elapsed", w1, w2)
+	}
+
+	r.now += 5
+	w3, err := r.edgeWeight(1, 2)
+	if err != nil {
+		t.Fatalf("edgeWeight: %v", err)
+	}
+	if w3 <= w2 {
+		t.Fatalf("expected recalculated weight to rise with congestion, got %v (was %v)", w3, w2)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
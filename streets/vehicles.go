@@ -3,6 +3,8 @@ package streets
 // Author: Valerius Mattfeld
 
 import (
+	"math"
+
 	"github.com/aidarkhanov/nanoid"
 	"github.com/gammazero/deque"
 	"github.com/rs/zerolog/log"
@@ -10,6 +12,15 @@ import (
 
 const NotInQueue = -1
 
+// SimulationDT is the simulated seconds advanced by a single Step.
+const SimulationDT = 1.0
+
+// Path is the sequence of vertices a Vehicle travels through, from its
+// origin to its destination.
+type Path struct {
+	Vertices []GVertex
+}
+
 // Vehicle represents a vehicle in the simulation
 type Vehicle struct {
 	ID          string
@@ -19,17 +30,49 @@ type Vehicle struct {
 	PathLength  *deque.Deque[float64]
 	IsParked    bool
 	CurrentEdge *Edge
-	// Length?
+	Length      float64 // m, used as the leader's bumper-to-bumper offset
+
+	// IDM car-following parameters, see drive().
+	DesiredSpeed float64 // v0, m/s; falls back to the current edge's MaxSpeed when 0
+	MinGap       float64 // s0, m
+	TimeHeadway  float64 // T, s
+	MaxAccel     float64 // a, m/s^2
+	ComfortDecel float64 // b, m/s^2
+
+	// Router, if set, lets the Vehicle recompute its Path mid-trip via Reroute.
+	Router        *Router
+	ReroutePolicy ReroutePolicy
+	lastRerouteAt Step
+
+	// HomePartition, if set, is consulted by GetCurrentEdge before the
+	// global graph index. A Partition built by NewPartition holds only
+	// topology (GraphFromRect attaches no EdgeData), so lookups through it
+	// fail and GetCurrentEdge resolves every edge through Graph.
+	HomePartition *Partition
+
+	// pathIndex is the index into Path.Vertices of the vertex this Vehicle
+	// is currently departing from. PathLength shrinks by one entry every
+	// time an edge completes, so it can't be used to index back into the
+	// (unshrunk) Path.Vertices itself; pathIndex is the one source of
+	// truth for "where in Path am I".
+	pathIndex int
 }
 
 // NewVehicle creates a new vehicle
 func NewVehicle(path Path, speed float64, graph Graph) Vehicle {
 	v := Vehicle{
-		ID:       nanoid.New(),
-		Speed:    speed,
-		Path:     path,
-		Graph:    &graph,
-		IsParked: false,
+		ID:            nanoid.New(),
+		Speed:         speed,
+		Path:          path,
+		Graph:         &graph,
+		IsParked:      false,
+		Length:        4.5,
+		DesiredSpeed:  speed,
+		MinGap:        2,
+		TimeHeadway:   1.5,
+		MaxAccel:      1.5,
+		ComfortDecel:  2.0,
+		ReroutePolicy: DefaultReroutePolicy,
 	}
 
 	var q deque.Deque[float64]
@@ -52,34 +95,80 @@ func (v *Vehicle) Step() {
 	v.drive()
 }
 
+// drive advances the Vehicle by one Step using an Intelligent Driver Model
+// (IDM) car-following rule: an unobstructed Vehicle accelerates towards its
+// DesiredSpeed, while a Vehicle behind a leader on the same edge brakes to
+// keep a speed-dependent safety gap. Each Step the Vehicle derives its own
+// distance along the current edge from PathLength and records it on the
+// Edge (Edge.SetPosition), so FrontVehicle can find the true nearest leader
+// by position regardless of queue order. "Parked at end" triggers once the traveled distance
+// reaches the edge length, rather than off queue index.
 func (v *Vehicle) drive() {
 	v.CurrentEdge = v.GetCurrentEdge()
+	if v.CurrentEdge == nil {
+		return
+	}
+
 	if v.CurrentEdge.GetPosition(v) == NotInQueue {
 		v.CurrentEdge.PushVehicle(v)
 		log.Info().Msgf("Vehicle %v has entered edge %v", v.ID, v.CurrentEdge.ID)
 		log.Info().Msgf("Vehicle %v is now at position %v", v.ID, v.CurrentEdge.GetPosition(v))
 	}
 
-	//if q.Len() == 0 {
-	//	v.IsParked = true
-	//	log.Info().Msgf("Vehicle %v has arrived at destination", v.ID)
-	//	return
-	//}
-	q := v.PathLength
+	edge := v.CurrentEdge
+	remaining := v.PathLength.Front()
+	traveled := edge.Length - remaining
+	edge.SetPosition(v, traveled)
 
-	if q.Back() <= v.Speed && q.Len() > 1 {
-		backM := q.PopBack()
-		bM := q.PopBack()
-		q.PushBack(backM + bM)
-	} else if q.Back() <= v.Speed && q.Len() == 1 {
-		q.PopBack()
+	v.accelerate(edge, traveled)
+	traveled += v.Speed * SimulationDT
+
+	if traveled >= edge.Length {
+		overflow := traveled - edge.Length
 		v.CurrentEdge.PopVehicle()
-		v.IsParked = true
-		log.Info().Msgf("Vehicle %v has arrived at destination", v.ID)
+		v.PathLength.PopFront()
+		v.pathIndex++
+
+		if v.PathLength.Len() == 0 {
+			v.IsParked = true
+			log.Info().Msgf("Vehicle %v has arrived at destination", v.ID)
+			return
+		}
+
+		next := v.PathLength.PopFront()
+		v.PathLength.PushFront(math.Max(0, next-overflow))
 		return
+	}
+
+	edge.SetPosition(v, traveled)
+	v.PathLength.PopFront()
+	v.PathLength.PushFront(edge.Length - traveled)
+}
+
+// accelerate applies one IDM step to v.Speed, given the edge it's currently
+// on and how far along that edge it has traveled.
+func (v *Vehicle) accelerate(edge *Edge, traveled float64) {
+	v0 := v.DesiredSpeed
+	if v0 <= 0 {
+		v0 = edge.MaxSpeed
+	}
+
+	var accel float64
+	if v.IsLeading() {
+		accel = IDMAccel(v.Speed, v0, math.Inf(1), v.Speed, v.MinGap, v.TimeHeadway, v.MaxAccel, v.ComfortDecel)
 	} else {
-		backLength := q.PopBack()
-		q.PushBack(backLength - v.Speed)
+		leader := edge.FrontVehicle(v)
+		gap := math.Max(0.01, edge.Position(leader)-traveled-leader.Length)
+
+		accel = IDMAccel(v.Speed, v0, gap, leader.Speed, v.MinGap, v.TimeHeadway, v.MaxAccel, v.ComfortDecel)
+	}
+
+	v.Speed += accel * SimulationDT
+	if v.Speed < 0 {
+		v.Speed = 0
+	}
+	if v.Speed > edge.MaxSpeed {
+		v.Speed = edge.MaxSpeed
 	}
 }
 
@@ -108,29 +197,36 @@ func (v *Vehicle) GetPathLengths() []float64 {
 	return lengths
 }
 
+// GetCurrentEdge returns the Edge between the vertex at v.pathIndex and the
+// next one in v.Path, i.e. the edge v is currently traversing (or about to
+// enter). It returns nil once v has reached the last vertex in its Path.
 func (v *Vehicle) GetCurrentEdge() *Edge {
 	if v.IsParked {
 		return nil
 	}
+	if v.pathIndex+1 >= len(v.Path.Vertices) {
+		return nil
+	}
 
-	var nonZeroIdx int
-
-	for i := 0; i < v.PathLength.Len(); i++ {
-		if v.PathLength.At(i) != 0 {
-			nonZeroIdx = i
-			break
+	from := &v.Path.Vertices[v.pathIndex]
+	to := &v.Path.Vertices[v.pathIndex+1]
+
+	// Consult the home partition's local graph first. An edge it cannot
+	// resolve -- one that crosses a partition boundary, or one without
+	// EdgeData -- falls through to the global lookup below; since
+	// GraphFromRect attaches no EdgeData, that is every edge of a Partition
+	// built by NewPartition.
+	if v.HomePartition != nil {
+		if edge, err := v.HomePartition.Graph.GetCorrespondingEdge(from, to); err == nil {
+			return edge
 		}
 	}
-	for idx, vertex := range v.Path.Vertices {
-		if idx == nonZeroIdx {
-			if edge, err := v.Graph.GetCorrespondingEdge(&vertex, &v.Path.Vertices[idx+1]); err != nil {
-				log.Panic().Err(err).Msg("Failed to get corresponding edge")
-			} else {
-				return edge
-			}
-		}
+
+	edge, err := v.Graph.GetCorrespondingEdge(from, to)
+	if err != nil {
+		log.Panic().Err(err).Msg("Failed to get corresponding edge")
 	}
-	return nil
+	return edge
 }
 
 func (v *Vehicle) IsLeading() bool {
@@ -0,0 +1,170 @@
+// Package viz renders streets.Graph and live simulation state as Graphviz
+// DOT, without depending on a DOT-building library: the text is simple
+// enough that emitting it directly keeps the module free of an extra dep.
+package viz
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dominikbraun/graph"
+
+	"pchpc/streets"
+)
+
+// Options controls how WriteDOT renders a graph.
+type Options struct {
+	// Name is the DOT graph name. Defaults to "streets" if empty.
+	Name string
+	// Directed emits a digraph instead of a graph. Streets graphs are
+	// directed, so this should normally be true.
+	Directed bool
+}
+
+func (o Options) graphName() string {
+	if o.Name == "" {
+		return "streets"
+	}
+	return o.Name
+}
+
+// WriteDOT writes g as Graphviz DOT to w. Vertices are pinned to their
+// (X, Y) coordinates via pos="x,y!" (requires the "neato" or "fdp" layout
+// engines), and edges are labeled with their MaxSpeed and Length. Edge color
+// reflects the current vehicle count on that edge, from cg's cached
+// *streets.Edge.Q: green when empty, shading towards red as it fills up.
+func WriteDOT(w io.Writer, g graph.Graph[int, streets.GVertex], cg *streets.Graph, opts Options) error {
+	kind := "graph"
+	arrow := "--"
+	if opts.Directed {
+		kind = "digraph"
+		arrow = "->"
+	}
+
+	fmt.Fprintf(w, "%s %q {\n", kind, opts.graphName())
+
+	vertices, err := streets.GetVertices(&g)
+	if err != nil {
+		return err
+	}
+	for _, v := range vertices {
+		fmt.Fprintf(w, "  %d [pos=\"%g,%g!\"];\n", v.ID, v.X, v.Y)
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return err
+	}
+	for _, e := range edges {
+		data, _ := e.Properties.Data.(streets.EdgeData)
+
+		occupancy := 0
+		from := streets.GVertex{ID: e.Source}
+		to := streets.GVertex{ID: e.Target}
+		if edge, err := cg.GetCorrespondingEdge(&from, &to); err == nil {
+			occupancy = edge.Q.Len()
+		}
+
+		fmt.Fprintf(w, "  %d %s %d [label=\"%gm @ %gm/s\", color=%q];\n",
+			e.Source, arrow, e.Target, data.Length, data.MaxSpeed, occupancyColor(occupancy))
+	}
+
+	fmt.Fprintln(w, "}")
+	return nil
+}
+
+// occupancyColor maps a vehicle count to a DOT color name, shading from
+// green (empty) to red (heavily occupied).
+func occupancyColor(n int) string {
+	switch {
+	case n == 0:
+		return "green"
+	case n <= 2:
+		return "yellow"
+	case n <= 5:
+		return "orange"
+	default:
+		return "red"
+	}
+}
+
+// WriteRectsDOT renders g with vertices shaded by the streets.Rect they
+// belong to, using a Graphviz cluster subgraph per rect.
+func WriteRectsDOT(w io.Writer, g graph.Graph[int, streets.GVertex], rects []streets.Rect, opts Options) error {
+	kind := "graph"
+	arrow := "--"
+	if opts.Directed {
+		kind = "digraph"
+		arrow = "->"
+	}
+
+	fmt.Fprintf(w, "%s %q {\n", kind, opts.graphName())
+
+	palette := []string{"lightblue", "lightpink", "lightyellow", "lightgreen", "lavender", "wheat"}
+	for i, rect := range rects {
+		color := palette[i%len(palette)]
+		fmt.Fprintf(w, "  subgraph \"cluster_%d\" {\n", i)
+		fmt.Fprintf(w, "    label=%q;\n    style=filled;\n    color=%q;\n", fmt.Sprintf("rect %d", i), color)
+		for _, v := range rect.Vertices {
+			fmt.Fprintf(w, "    %d [pos=\"%g,%g!\"];\n", v.ID, v.X, v.Y)
+		}
+		fmt.Fprintln(w, "  }")
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return err
+	}
+	for _, e := range edges {
+		fmt.Fprintf(w, "  %d %s %d;\n", e.Source, arrow, e.Target)
+	}
+
+	fmt.Fprintln(w, "}")
+	return nil
+}
+
+// WriteVehicleOverlayDOT renders g's vertices like WriteDOT, but labels each
+// edge with the ordered list of Vehicle IDs currently queued on it, in place
+// of WriteDOT's MaxSpeed/Length label and occupancy color.
+// cg resolves each library edge to its *streets.Edge, whose Q holds the
+// real FIFO queue order; EdgeData.Map is an unordered hash map and can't be
+// used to produce a stable ordering.
+func WriteVehicleOverlayDOT(w io.Writer, g graph.Graph[int, streets.GVertex], cg *streets.Graph, opts Options) error {
+	kind := "graph"
+	arrow := "--"
+	if opts.Directed {
+		kind = "digraph"
+		arrow = "->"
+	}
+
+	fmt.Fprintf(w, "%s %q {\n", kind, opts.graphName())
+
+	vertices, err := streets.GetVertices(&g)
+	if err != nil {
+		return err
+	}
+	for _, v := range vertices {
+		fmt.Fprintf(w, "  %d [pos=\"%g,%g!\"];\n", v.ID, v.X, v.Y)
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return err
+	}
+	for _, e := range edges {
+		from := streets.GVertex{ID: e.Source}
+		to := streets.GVertex{ID: e.Target}
+
+		ids := make([]string, 0)
+		if edge, err := cg.GetCorrespondingEdge(&from, &to); err == nil {
+			for i := 0; i < edge.Q.Len(); i++ {
+				ids = append(ids, edge.Q.At(i).ID)
+			}
+		}
+
+		fmt.Fprintf(w, "  %d %s %d [label=\"%v\"];\n", e.Source, arrow, e.Target, ids)
+	}
+
+	fmt.Fprintln(w, "}")
+	return nil
+}
@@ -0,0 +1,89 @@
+package streets
+
+import "math"
+
+// EdgeWeight computes the cost of traversing an edge at simulation time t.
+// Router uses it to build the per-edge weight table Dijkstra runs over, so
+// scenario authors can script rush hours, road closures, or other
+// time-varying costs without touching the routing algorithm itself.
+type EdgeWeight interface {
+	Cost(e *Edge, t Step) float64
+}
+
+// LengthOverMaxSpeed is the baseline free-flow cost: Length / MaxSpeed,
+// ignoring occupancy entirely.
+type LengthOverMaxSpeed struct{}
+
+func (LengthOverMaxSpeed) Cost(e *Edge, _ Step) float64 {
+	if e.MaxSpeed <= 0 {
+		return math.Inf(1)
+	}
+	return e.Length / e.MaxSpeed
+}
+
+// QueueAware adds a penalty proportional to the number of vehicles
+// currently queued on the edge to the free-flow cost, so routing mildly
+// avoids busy edges without the full congestion-speed model.
+type QueueAware struct {
+	// PenaltyPerVehicle is the extra cost (seconds) added per queued vehicle.
+	PenaltyPerVehicle float64
+}
+
+func (q QueueAware) Cost(e *Edge, t Step) float64 {
+	base := (LengthOverMaxSpeed{}).Cost(e, t)
+	return base + float64(e.Q.Len())*q.PenaltyPerVehicle
+}
+
+// CongestionAware derives an effective travel time from occupancy the same
+// way Router did before EdgeWeight existed: Length / max(MinSpeed,
+// MaxSpeed*(1-congestion)), where congestion grows with queue length. This
+// is the Router's default weighter.
+type CongestionAware struct{}
+
+func (CongestionAware) Cost(e *Edge, _ Step) float64 {
+	effectiveSpeed := math.Max(MinSpeed, e.MaxSpeed*(1-congestionFactor(e.Q.Len())))
+	return e.Length / effectiveSpeed
+}
+
+// TimeWindow scales an edge's cost during [From, To). A Multiplier of
+// math.Inf(1) closes the edge for the duration of the window, which Router
+// treats as unreachable.
+type TimeWindow struct {
+	From       Step
+	To         Step
+	Multiplier float64
+}
+
+func (w TimeWindow) active(t Step) bool {
+	return t >= w.From && t < w.To
+}
+
+// windowed is implemented by graph-building types (e.g. JEdge) that carry
+// optional TimeWindows to attach to EdgeData without this package needing
+// to know their concrete type.
+type windowed interface {
+	TimeWindows() []TimeWindow
+}
+
+// Scheduled wraps another EdgeWeight and scales its cost by every TimeWindow
+// on EdgeData.Windows that is active at t (overlapping windows multiply),
+// letting scenario authors script rush hours or road closures on top of any
+// base weighter.
+type Scheduled struct {
+	Base EdgeWeight
+}
+
+func (s Scheduled) Cost(e *Edge, t Step) float64 {
+	base := s.Base.Cost(e, t)
+
+	for _, window := range e.Windows {
+		if window.active(t) {
+			if math.IsInf(window.Multiplier, 1) {
+				return math.Inf(1)
+			}
+			base *= window.Multiplier
+		}
+	}
+
+	return base
+}
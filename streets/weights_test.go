@@ -0,0 +1,74 @@
+package streets
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScheduledClosesEdgeDuringWindow(t *testing.T) {
+	g := buildDiamondGraph(t, 0)
+	r := NewRouter(g, 0)
+	r.Weight = Scheduled{Base: LengthOverMaxSpeed{}}
+
+	// Close the short route (2->4) for steps [100, 200). Set this on the
+	// cached *Edge itself, before it's ever resolved by the Router, since
+	// Graph.GetCorrespondingEdge caches the Edge it builds the first time
+	// an edge is looked up -- a later change to the library graph's
+	// EdgeData wouldn't reach an already-cached Edge.
+	from, to := GVertex{ID: 2}, GVertex{ID: 4}
+	edge, err := g.GetCorrespondingEdge(&from, &to)
+	if err != nil {
+		t.Fatalf("GetCorrespondingEdge(2, 4): %v", err)
+	}
+	edge.Windows = []TimeWindow{{From: 100, To: 200, Multiplier: math.Inf(1)}}
+
+	before, _, err := r.ShortestPath(1, 4)
+	if err != nil {
+		t.Fatalf("ShortestPath before closure: %v", err)
+	}
+	if !equalInts(before, []int{1, 2, 4}) {
+		t.Fatalf("expected the short route before the closure window, got %v", before)
+	}
+
+	r.now = 150
+	after, _, err := r.ShortestPath(1, 4)
+	if err != nil {
+		t.Fatalf("ShortestPath during closure: %v", err)
+	}
+	if !equalInts(after, []int{1, 3, 4}) {
+		t.Fatalf("expected vehicles to re-plan around the closed edge, got %v", after)
+	}
+}
+
+// TestVehicleReroutesAroundScheduledClosure exercises the request's actual
+// ask -- a waiting vehicle re-plans through its Router -- rather than just
+// Router.ShortestPath in isolation.
+func TestVehicleReroutesAroundScheduledClosure(t *testing.T) {
+	g := buildDiamondGraph(t, 0)
+	r := NewRouter(g, 0)
+	r.Weight = Scheduled{Base: LengthOverMaxSpeed{}}
+	r.now = 150
+
+	from, to := GVertex{ID: 2}, GVertex{ID: 4}
+	edge, err := g.GetCorrespondingEdge(&from, &to)
+	if err != nil {
+		t.Fatalf("GetCorrespondingEdge(2, 4): %v", err)
+	}
+	edge.Windows = []TimeWindow{{From: 100, To: 200, Multiplier: math.Inf(1)}}
+
+	path := Path{Vertices: []GVertex{{ID: 1}, {ID: 2}, {ID: 4}}}
+	v := NewVehicle(path, 10, *g)
+	v.Router = r
+
+	if err := v.Reroute(GVertex{ID: 4}); err != nil {
+		t.Fatalf("Reroute: %v", err)
+	}
+
+	got := make([]int, len(v.Path.Vertices))
+	for i, vertex := range v.Path.Vertices {
+		got[i] = vertex.ID
+	}
+	if !equalInts(got, []int{1, 3, 4}) {
+		t.Fatalf("expected the waiting vehicle to re-plan around the closed edge, got %v", got)
+	}
+}
@@ -0,0 +1,44 @@
+// Package utils holds small generic helpers shared across the streets
+// packages.
+package utils
+
+// HashMap is a thin generic wrapper around a Go map, giving callers a named
+// type to embed (e.g. EdgeData.Map) instead of a bare map[K]V.
+type HashMap[K comparable, V any] struct {
+	m map[K]V
+}
+
+// NewMap creates an empty HashMap.
+func NewMap[K comparable, V any]() HashMap[K, V] {
+	return HashMap[K, V]{m: make(map[K]V)}
+}
+
+// Set stores value under key.
+func (h *HashMap[K, V]) Set(key K, value V) {
+	h.m[key] = value
+}
+
+// Get returns the value stored under key, and whether it was present.
+func (h *HashMap[K, V]) Get(key K) (V, bool) {
+	v, ok := h.m[key]
+	return v, ok
+}
+
+// Delete removes key from the map, if present.
+func (h *HashMap[K, V]) Delete(key K) {
+	delete(h.m, key)
+}
+
+// Len returns the number of entries currently stored.
+func (h *HashMap[K, V]) Len() int {
+	return len(h.m)
+}
+
+// Keys returns the map's keys in unspecified order.
+func (h *HashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(h.m))
+	for k := range h.m {
+		keys = append(keys, k)
+	}
+	return keys
+}